@@ -39,8 +39,14 @@ func get_all_system_test_targets() ([]string, error) {
 	return all_targets, nil
 }
 
+// get_closest_target_matches deliberately takes a raw string rather than a
+// TargetRef: it exists precisely for loosely-typed queries like "subnet
+// recovery" that are never valid Bazel labels, so gating it behind
+// TargetRef.Parse would reject the exact input this function is for.
+// check_target_exists is the one that stays strict, since that's the point
+// where we're actually about to spawn bazel on the user's behalf.
 func get_closest_target_matches(target string) ([]string, error) {
-	all_targets, err := get_all_system_test_targets()
+	all_targets, err := get_all_system_test_targets_cached()
 	if err != nil {
 		return []string{}, err
 	}
@@ -50,8 +56,8 @@ func get_closest_target_matches(target string) ([]string, error) {
 	}), nil
 }
 
-func check_target_exists(target string) (bool, error) {
-	command := []string{"bazel", "query", target}
+func check_target_exists(target TargetRef) (bool, error) {
+	command := []string{"bazel", "query", target.String()}
 	queryCmd := exec.Command(command[0], command[1:]...)
 	stdErrBuffer := &bytes.Buffer{}
 	queryCmd.Stderr = stdErrBuffer
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// MatchMode selects the strategy used by get_target_matches to pick
+// candidate targets for a user-supplied query.
+type MatchMode string
+
+const (
+	// MatchFuzzy is the default bag-of-words fuzzy match via closestmatch.
+	MatchFuzzy MatchMode = "fuzzy"
+	// MatchSubstring splits the query on whitespace and requires every
+	// resulting token to appear as a substring of the candidate, mirroring
+	// lazygit's default filter behaviour.
+	MatchSubstring MatchMode = "substring"
+	// MatchRegex compiles the query as a regular expression and matches it
+	// against each candidate.
+	MatchRegex MatchMode = "regex"
+	// MatchGlob matches the query as a shell glob against the `//rs/tests:`
+	// suffix of each candidate.
+	MatchGlob MatchMode = "glob"
+)
+
+// --match-mode flag value, defaulting to the historical fuzzy behaviour.
+var matchModeFlag string = string(MatchFuzzy)
+
+func parseMatchMode(s string) (MatchMode, error) {
+	switch MatchMode(s) {
+	case MatchFuzzy, MatchSubstring, MatchRegex, MatchGlob:
+		return MatchMode(s), nil
+	default:
+		return "", fmt.Errorf("unknown match mode %q: want one of fuzzy, substring, regex, glob", s)
+	}
+}
+
+func matchSubstring(query string, targets []string) []string {
+	tokens := strings.Fields(query)
+	return Filter(targets, func(target string) bool {
+		for _, token := range tokens {
+			if !strings.Contains(target, token) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func matchRegex(query string, targets []string) ([]string, error) {
+	re, err := regexp.Compile(query)
+	if err != nil {
+		return []string{}, fmt.Errorf("invalid --match-mode=regex pattern %q: %w", query, err)
+	}
+	return Filter(targets, re.MatchString), nil
+}
+
+func matchGlob(query string, targets []string) ([]string, error) {
+	matched := make([]string, 0)
+	for _, target := range targets {
+		suffix := strings.TrimPrefix(target, "//rs/tests:")
+		ok, err := path.Match(query, suffix)
+		if err != nil {
+			return []string{}, fmt.Errorf("invalid --match-mode=glob pattern %q: %w", query, err)
+		}
+		if ok {
+			matched = append(matched, target)
+		}
+	}
+	return matched, nil
+}
+
+// allSystemTestTargetsFunc is get_all_system_test_targets_cached by default;
+// tests override it so MatchSubstring/MatchRegex/MatchGlob dispatch can be
+// exercised without shelling out to bazel.
+var allSystemTestTargetsFunc = get_all_system_test_targets_cached
+
+// get_target_matches resolves target using the given MatchMode, falling back
+// to the original closestmatch fuzzy search for MatchFuzzy.
+func get_target_matches(target string, mode MatchMode) ([]string, error) {
+	if mode == MatchFuzzy {
+		return get_closest_target_matches(target)
+	}
+
+	all_targets, err := allSystemTestTargetsFunc()
+	if err != nil {
+		return []string{}, err
+	}
+
+	switch mode {
+	case MatchSubstring:
+		return matchSubstring(target, all_targets), nil
+	case MatchRegex:
+		return matchRegex(target, all_targets)
+	case MatchGlob:
+		return matchGlob(target, all_targets)
+	default:
+		return []string{}, fmt.Errorf("unknown match mode %q", mode)
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import "testing"
+
+func TestParseMatchMode(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    MatchMode
+		wantErr bool
+	}{
+		{"fuzzy", MatchFuzzy, false},
+		{"substring", MatchSubstring, false},
+		{"regex", MatchRegex, false},
+		{"glob", MatchGlob, false},
+		{"bogus", "", true},
+		{"", "", true},
+	}
+	for _, tc := range cases {
+		got, err := parseMatchMode(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("parseMatchMode(%q) error = %v, wantErr %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if err == nil && got != tc.want {
+			t.Errorf("parseMatchMode(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestMatchSubstring(t *testing.T) {
+	targets := []string{
+		"//rs/tests:subnet_recovery_test",
+		"//rs/tests:subnet_splitting_test",
+		"//rs/tests:icrc1_test",
+	}
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{"subnet recovery", []string{"//rs/tests:subnet_recovery_test"}},
+		{"subnet", []string{"//rs/tests:subnet_recovery_test", "//rs/tests:subnet_splitting_test"}},
+		{"nope", nil},
+	}
+	for _, tc := range cases {
+		got := matchSubstring(tc.query, targets)
+		if len(got) != len(tc.want) {
+			t.Errorf("matchSubstring(%q) = %v, want %v", tc.query, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("matchSubstring(%q) = %v, want %v", tc.query, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestMatchRegex(t *testing.T) {
+	targets := []string{"//rs/tests:subnet_recovery_test", "//rs/tests:icrc1_test"}
+
+	got, err := matchRegex("^//rs/tests:subnet.*", targets)
+	if err != nil {
+		t.Fatalf("matchRegex returned error: %v", err)
+	}
+	if len(got) != 1 || got[0] != "//rs/tests:subnet_recovery_test" {
+		t.Errorf("matchRegex = %v, want [//rs/tests:subnet_recovery_test]", got)
+	}
+
+	if _, err := matchRegex("(", targets); err == nil {
+		t.Error("matchRegex with invalid pattern should return an error")
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	targets := []string{"//rs/tests:subnet_recovery_test", "//rs/tests:icrc1_test"}
+
+	got, err := matchGlob("subnet_*", targets)
+	if err != nil {
+		t.Fatalf("matchGlob returned error: %v", err)
+	}
+	if len(got) != 1 || got[0] != "//rs/tests:subnet_recovery_test" {
+		t.Errorf("matchGlob = %v, want [//rs/tests:subnet_recovery_test]", got)
+	}
+
+	if _, err := matchGlob("[", targets); err == nil {
+		t.Error("matchGlob with invalid pattern should return an error")
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// OutputFormat selects how target resolution results are rendered.
+type OutputFormat string
+
+const (
+	// OutputText is the default human-readable prose output.
+	OutputText OutputFormat = "text"
+	OutputJSON OutputFormat = "json"
+	OutputTSV  OutputFormat = "tsv"
+)
+
+// --output flag value.
+var outputFormatFlag string = string(OutputText)
+
+func parseOutputFormat(s string) (OutputFormat, error) {
+	switch OutputFormat(s) {
+	case OutputText, OutputJSON, OutputTSV:
+		return OutputFormat(s), nil
+	default:
+		return "", fmt.Errorf("unknown output format %q: want one of text, json, tsv", s)
+	}
+}
+
+// Suggestion is a single ranked candidate target, scored by its position in
+// the match-mode-specific result list (see rank_suggestions).
+type Suggestion struct {
+	Target string  `json:"target"`
+	Score  float64 `json:"score"`
+}
+
+// QueryResult is the machine-readable result of resolving a single target
+// query, as emitted by --output=json and --output=tsv.
+type QueryResult struct {
+	Query       string       `json:"query"`
+	Exists      bool         `json:"exists"`
+	Suggestions []Suggestion `json:"suggestions"`
+}
+
+// rank_suggestions turns an already mode-ranked (or, for substring/regex/glob,
+// unordered) list of matches into scored Suggestions, capped to the top n.
+// Only fuzzy mode's ordering (via closestmatch.ClosestN) carries real
+// relevance information; the other modes are simple filters, so their score
+// is likewise just a rank placeholder (1.0 = first result, decreasing
+// towards 0) rather than a measure of closeness.
+func rank_suggestions(matches []string, n int) []Suggestion {
+	if n > 0 && len(matches) > n {
+		matches = matches[:n]
+	}
+
+	suggestions := make([]Suggestion, len(matches))
+	total := len(matches)
+	for i, candidate := range matches {
+		suggestions[i] = Suggestion{
+			Target: candidate,
+			Score:  float64(total-i) / float64(total),
+		}
+	}
+	return suggestions
+}
+
+// resolve_target builds the full QueryResult for a single query: whether it
+// exists verbatim plus its ranked suggestions, computed via the same
+// get_target_matches(target, mode) dispatch the text-output path uses, so
+// --output=json/tsv agree with plain-text output for every --match-mode.
+func resolve_target(target string, mode MatchMode, n int) (QueryResult, error) {
+	// An invalid label just means "doesn't exist verbatim" here, not a hard
+	// error: we still want suggestions for loosely-typed queries like
+	// "subnet recovery", and only need a well-formed TargetRef to spawn
+	// bazel for the exact-match check.
+	exists := false
+	if ref, err := Parse(target); err == nil {
+		exists, err = check_target_exists(ref)
+		if err != nil {
+			return QueryResult{}, err
+		}
+	}
+
+	matches, err := get_target_matches(target, mode)
+	if err != nil {
+		return QueryResult{}, err
+	}
+
+	return QueryResult{
+		Query:       target,
+		Exists:      exists,
+		Suggestions: rank_suggestions(matches, n),
+	}, nil
+}
+
+func write_query_result(w io.Writer, result QueryResult, format OutputFormat) error {
+	switch format {
+	case OutputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case OutputTSV:
+		lines := make([]string, 0, len(result.Suggestions)+1)
+		lines = append(lines, fmt.Sprintf("%s\t%t", result.Query, result.Exists))
+		for _, s := range result.Suggestions {
+			lines = append(lines, fmt.Sprintf("%s\t%.4f", s.Target, s.Score))
+		}
+		_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+		return err
+	default:
+		return fmt.Errorf("write_query_result does not support format %q; use OutputJSON or OutputTSV", format)
+	}
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseOutputFormat(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    OutputFormat
+		wantErr bool
+	}{
+		{"text", OutputText, false},
+		{"json", OutputJSON, false},
+		{"tsv", OutputTSV, false},
+		{"xml", "", true},
+	}
+	for _, tc := range cases {
+		got, err := parseOutputFormat(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("parseOutputFormat(%q) error = %v, wantErr %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if err == nil && got != tc.want {
+			t.Errorf("parseOutputFormat(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestRankSuggestionsDescendingAndCapped(t *testing.T) {
+	matches := []string{
+		"//rs/tests:subnet_recovery_test",
+		"//rs/tests:subnet_splitting_test",
+		"//rs/tests:icrc1_test",
+	}
+
+	suggestions := rank_suggestions(matches, 2)
+	if len(suggestions) != 2 {
+		t.Fatalf("rank_suggestions() returned %d suggestions, want 2", len(suggestions))
+	}
+	if suggestions[0].Target != matches[0] || suggestions[1].Target != matches[1] {
+		t.Errorf("rank_suggestions() = %+v, want the input order preserved", suggestions)
+	}
+	if suggestions[0].Score <= suggestions[1].Score {
+		t.Errorf("suggestions not ranked descending by score: %+v", suggestions)
+	}
+}
+
+// TestResolveTargetDispatchesThroughMatchMode guards against the
+// regression where --output=json/tsv always used fuzzy closestmatch ranking
+// regardless of --match-mode: resolve_target must route through
+// get_target_matches(target, mode) for every mode.
+func TestResolveTargetDispatchesThroughMatchMode(t *testing.T) {
+	all_targets := []string{
+		"//rs/tests:subnet_recovery_test",
+		"//rs/tests:subnet_splitting_test",
+		"//rs/tests:icrc1_test",
+	}
+
+	old := allSystemTestTargetsFunc
+	allSystemTestTargetsFunc = func() ([]string, error) { return all_targets, nil }
+	defer func() { allSystemTestTargetsFunc = old }()
+
+	result, err := resolve_target("^//rs/tests:subnet.*", MatchRegex, 10)
+	if err != nil {
+		t.Fatalf("resolve_target() error = %v", err)
+	}
+
+	want := map[string]bool{
+		"//rs/tests:subnet_recovery_test":  true,
+		"//rs/tests:subnet_splitting_test": true,
+	}
+	if len(result.Suggestions) != len(want) {
+		t.Fatalf("resolve_target() suggestions = %+v, want exactly the regex-matched subnet targets", result.Suggestions)
+	}
+	for _, s := range result.Suggestions {
+		if !want[s.Target] {
+			t.Errorf("resolve_target() included %q, which the regex shouldn't match", s.Target)
+		}
+	}
+}
+
+func TestWriteQueryResultJSON(t *testing.T) {
+	result := QueryResult{
+		Query:       "subnet_recovery",
+		Exists:      false,
+		Suggestions: []Suggestion{{Target: "//rs/tests:subnet_recovery_test", Score: 1.0}},
+	}
+
+	var buf bytes.Buffer
+	if err := write_query_result(&buf, result, OutputJSON); err != nil {
+		t.Fatalf("write_query_result() error = %v", err)
+	}
+
+	var got QueryResult
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if got.Query != result.Query || got.Exists != result.Exists || len(got.Suggestions) != 1 {
+		t.Errorf("write_query_result() round-tripped to %+v, want %+v", got, result)
+	}
+}
+
+func TestWriteQueryResultTSV(t *testing.T) {
+	result := QueryResult{
+		Query:       "subnet_recovery",
+		Exists:      true,
+		Suggestions: []Suggestion{{Target: "//rs/tests:subnet_recovery_test", Score: 1.0}},
+	}
+
+	var buf bytes.Buffer
+	if err := write_query_result(&buf, result, OutputTSV); err != nil {
+		t.Fatalf("write_query_result() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "subnet_recovery\ttrue") {
+		t.Errorf("write_query_result() tsv = %q, missing query/exists header line", out)
+	}
+	if !strings.Contains(out, "//rs/tests:subnet_recovery_test\t1.0000") {
+		t.Errorf("write_query_result() tsv = %q, missing suggestion line", out)
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var resolveCmd = &cobra.Command{
+	Use:   "resolve <target>...",
+	Short: "Resolve system test targets, printing close matches when a target does not exist",
+	Args:  cobra.ArbitraryArgs,
+	RunE:  runResolve,
+}
+
+func init() {
+	resolveCmd.Flags().StringVar(&matchModeFlag, "match-mode", matchModeFlag, "matching strategy for suggestions when a target is not found: fuzzy, substring, regex, glob")
+	resolveCmd.Flags().StringVar(&outputFormatFlag, "output", outputFormatFlag, "output format: text, json, tsv")
+	rootCmd.AddCommand(resolveCmd)
+}
+
+func runResolve(cmd *cobra.Command, args []string) error {
+	mode, err := parseMatchMode(matchModeFlag)
+	if err != nil {
+		return err
+	}
+	format, err := parseOutputFormat(outputFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	targets, err := resolve_targets_from_stdin(args)
+	if err != nil {
+		return err
+	}
+
+	if format != OutputText {
+		for _, target := range targets {
+			result, err := resolve_target(target, mode, FUZZY_MATCHES_COUNT)
+			if err != nil {
+				return err
+			}
+			if err := write_query_result(os.Stdout, result, format); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Only well-formed labels are worth spawning bazel for; a query typed for
+	// substring/regex/glob matching (e.g. a regex with `.*`) is never a valid
+	// TargetRef and should fall straight through to suggestion matching.
+	refs := make([]TargetRef, 0, len(targets))
+	refTargets := make([]string, 0, len(targets))
+	for _, target := range targets {
+		if ref, err := Parse(target); err == nil {
+			refs = append(refs, ref)
+			refTargets = append(refTargets, target)
+		}
+	}
+
+	existence, err := check_targets_exist(refs)
+	if err != nil {
+		return err
+	}
+	exists := make(map[string]bool, len(refTargets))
+	for i, target := range refTargets {
+		exists[target] = existence[i].Exists
+	}
+
+	for _, target := range targets {
+		if exists[target] {
+			fmt.Printf("%s: found\n", target)
+			continue
+		}
+
+		matches, err := get_target_matches(target, mode)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: not found, did you mean one of:\n", target)
+		for _, m := range matches {
+			fmt.Printf("  %s\n", m)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+)
+
+// read_targets_from_stdin scans newline-separated targets from r, skipping
+// blank lines. It mirrors ghq's sliceScanner/stdin handling so target lists
+// can be piped in, e.g. `cat targets.txt | ./ict resolve`.
+func read_targets_from_stdin(r io.Reader) ([]string, error) {
+	targets := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		targets = append(targets, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return []string{}, fmt.Errorf("reading targets from stdin: %w", err)
+	}
+	return targets, nil
+}
+
+// resolve_targets_from_stdin reads targets from stdin when no target args
+// were given on the command line, erroring out with a helpful message if
+// stdin is a terminal (i.e. there is nothing to pipe in).
+func resolve_targets_from_stdin(args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	if isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd()) {
+		return []string{}, fmt.Errorf("no targets given and stdin is a terminal: pass targets as arguments or pipe them in, e.g. `cat targets.txt | ./ict resolve`")
+	}
+	return read_targets_from_stdin(os.Stdin)
+}
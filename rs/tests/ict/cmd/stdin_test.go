@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadTargetsFromStdin(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"basic", "//rs/tests:a\n//rs/tests:b\n", []string{"//rs/tests:a", "//rs/tests:b"}},
+		{"blank lines skipped", "//rs/tests:a\n\n\n//rs/tests:b\n", []string{"//rs/tests:a", "//rs/tests:b"}},
+		{"trims whitespace", "  //rs/tests:a  \n", []string{"//rs/tests:a"}},
+		{"no trailing newline", "//rs/tests:a", []string{"//rs/tests:a"}},
+		{"empty input", "", []string{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := read_targets_from_stdin(strings.NewReader(tc.input))
+			if err != nil {
+				t.Fatalf("read_targets_from_stdin() error = %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("read_targets_from_stdin() = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("read_targets_from_stdin() = %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveTargetsFromStdinPrefersArgs(t *testing.T) {
+	got, err := resolve_targets_from_stdin([]string{"//rs/tests:a"})
+	if err != nil {
+		t.Fatalf("resolve_targets_from_stdin() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != "//rs/tests:a" {
+		t.Fatalf("resolve_targets_from_stdin() = %v, want [//rs/tests:a]", got)
+	}
+}
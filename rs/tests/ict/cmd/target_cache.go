@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// targetCacheFile is the on-disk cache of get_all_system_test_targets,
+// invalidated whenever the modification time of rs/tests/BUILD.bazel differs
+// from the one recorded when the cache was written.
+type targetCacheFile struct {
+	BuildFileModTime int64    `json:"build_file_mod_time"`
+	Targets          []string `json:"targets"`
+}
+
+func targetCachePath() (string, error) {
+	cacheDir := os.Getenv("XDG_CACHE_HOME")
+	if cacheDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("resolving cache dir: %w", err)
+		}
+		cacheDir = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(cacheDir, "ic", "targets.json"), nil
+}
+
+func buildFileModTime() (int64, error) {
+	info, err := os.Stat("rs/tests/BUILD.bazel")
+	if err != nil {
+		return 0, err
+	}
+	return info.ModTime().Unix(), nil
+}
+
+// get_all_system_test_targets_cached returns the cached target list when it
+// is still fresh relative to rs/tests/BUILD.bazel, otherwise it re-runs the
+// bazel query and refreshes the cache. When no cache path can be resolved or
+// rs/tests/BUILD.bazel cannot be stat'ed, the cache is neither read nor
+// written and every call runs the bazel query.
+func get_all_system_test_targets_cached() ([]string, error) {
+	cachePath, err := targetCachePath()
+	if err != nil {
+		return get_all_system_test_targets()
+	}
+
+	buildModTime, buildErr := buildFileModTime()
+
+	if data, err := os.ReadFile(cachePath); err == nil && buildErr == nil {
+		var cached targetCacheFile
+		if err := json.Unmarshal(data, &cached); err == nil && cached.BuildFileModTime == buildModTime {
+			return cached.Targets, nil
+		}
+	}
+
+	targets, err := get_all_system_test_targets()
+	if err != nil {
+		return []string{}, err
+	}
+
+	if buildErr == nil {
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0o755); err == nil {
+			data, err := json.Marshal(targetCacheFile{BuildFileModTime: buildModTime, Targets: targets})
+			if err == nil {
+				_ = writeFileAtomic(cachePath, data, 0o644)
+			}
+		}
+	}
+
+	return targets, nil
+}
+
+// writeFileAtomic writes data to a temp file in the same directory as path
+// and renames it into place, so a concurrent reader (e.g. another `ict`
+// invocation warming the same cache) never observes a partially written
+// targets.json.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// targetExistsResult pairs a queried target with its existence check outcome.
+type targetExistsResult struct {
+	Target string
+	Exists bool
+}
+
+// check_targets_exist runs check_target_exists for every target in parallel
+// using an errgroup, bounding concurrency to avoid overwhelming bazel with
+// simultaneous queries.
+func check_targets_exist(targets []TargetRef) ([]targetExistsResult, error) {
+	results := make([]targetExistsResult, len(targets))
+
+	g := new(errgroup.Group)
+	g.SetLimit(8)
+	for i, target := range targets {
+		i, target := i, target
+		g.Go(func() error {
+			exists, err := check_target_exists(target)
+			if err != nil {
+				return err
+			}
+			results[i] = targetExistsResult{Target: target.String(), Exists: exists}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
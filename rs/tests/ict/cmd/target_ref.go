@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// targetRefPattern matches Bazel label grammar: an optional `@repo` prefix,
+// a `//package/path` portion, and either `:name` or a `...` recursive
+// suffix, e.g. `@foo//rs/tests:name`, `//rs/tests:name`, `//rs/tests/...`.
+var targetRefPattern = regexp.MustCompile(`^(@[\w.-]+)?//[\w./-]*(:[\w.+-]+|\.\.\.)$`)
+
+// TargetRef is a validated, normalized reference to a Bazel target,
+// analogous to the distribution/reference package's handling of image
+// references.
+type TargetRef struct {
+	raw string
+}
+
+// String returns the fully-qualified label this TargetRef was parsed from.
+func (t TargetRef) String() string {
+	return t.raw
+}
+
+// Parse validates s against the Bazel label grammar, normalizing the
+// shorthand forms `:foo` and `foo` to `//rs/tests:foo` so users don't have
+// to type the full label.
+func Parse(s string) (TargetRef, error) {
+	if s == "" {
+		return TargetRef{}, fmt.Errorf("target reference must not be empty")
+	}
+
+	normalized := normalizeTargetRef(s)
+	if !targetRefPattern.MatchString(normalized) {
+		return TargetRef{}, fmt.Errorf("invalid target reference %q: want a Bazel label like //rs/tests:name", s)
+	}
+
+	return TargetRef{raw: normalized}, nil
+}
+
+func normalizeTargetRef(s string) string {
+	switch {
+	case len(s) > 2 && s[:2] == "//":
+		return s
+	case len(s) > 0 && s[0] == '@':
+		return s
+	case len(s) > 0 && s[0] == ':':
+		return "//rs/tests" + s
+	default:
+		return "//rs/tests:" + s
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"//rs/tests:subnet_recovery_test", "//rs/tests:subnet_recovery_test", false},
+		{":subnet_recovery_test", "//rs/tests:subnet_recovery_test", false},
+		{"subnet_recovery_test", "//rs/tests:subnet_recovery_test", false},
+		{"@repo//rs/tests:subnet_recovery_test", "@repo//rs/tests:subnet_recovery_test", false},
+		{"//rs/tests/...", "//rs/tests/...", false},
+		{"", "", true},
+		{"//", "", true},
+	}
+	for _, tc := range cases {
+		got, err := Parse(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("Parse(%q) error = %v, wantErr %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if err == nil && got.String() != tc.want {
+			t.Errorf("Parse(%q) = %q, want %q", tc.in, got.String(), tc.want)
+		}
+	}
+}
+
+func TestParseRejectsLooselyTypedFuzzyQueries(t *testing.T) {
+	// These are exactly the kind of "I know part of the name" queries
+	// fuzzy/substring matching exists for; Parse should reject them as
+	// target references (they're not meant to spawn bazel), not silently
+	// normalize them into a garbage label.
+	for _, query := range []string{"subnet recovery", "subnet_recovery*", "subnet[0-9]+"} {
+		if _, err := Parse(query); err == nil {
+			t.Errorf("Parse(%q) = nil error, want error for non-label query", query)
+		}
+	}
+}
+
+func TestGetTargetMatchesDoesNotRequireAValidLabel(t *testing.T) {
+	// get_target_matches must not reject loosely-typed fuzzy queries before
+	// it ever gets a chance to compute suggestions (regression: a query like
+	// "subnet recovery" previously failed TargetRef.Parse and errored out
+	// instead of returning fuzzy matches). Fuzzy mode always shells out to
+	// bazel, so this stubs the target list and asserts the non-fuzzy modes,
+	// which take the same raw query string, accept a query containing a space.
+	old := allSystemTestTargetsFunc
+	allSystemTestTargetsFunc = func() ([]string, error) {
+		return []string{"//rs/tests:subnet_recovery_test", "//rs/tests:icrc1_test"}, nil
+	}
+	defer func() { allSystemTestTargetsFunc = old }()
+
+	if _, err := get_target_matches("subnet recovery", MatchRegex); err != nil {
+		t.Errorf("get_target_matches with a spaced regex query should not error on the query itself: %v", err)
+	}
+
+	got, err := get_target_matches("subnet recovery", MatchSubstring)
+	if err != nil {
+		t.Fatalf("get_target_matches(%q, MatchSubstring) error = %v", "subnet recovery", err)
+	}
+	if len(got) != 1 || got[0] != "//rs/tests:subnet_recovery_test" {
+		t.Errorf("get_target_matches(%q, MatchSubstring) = %v, want [//rs/tests:subnet_recovery_test]", "subnet recovery", got)
+	}
+}
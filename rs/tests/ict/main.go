@@ -0,0 +1,7 @@
+package main
+
+import "github.com/dfinity/ic/rs/tests/ict/cmd"
+
+func main() {
+	cmd.Execute()
+}